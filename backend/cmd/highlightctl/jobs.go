@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/highlight-run/highlight/backend/lambda-functions/deleteSessions/handlers"
+)
+
+func runJobsCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: highlightctl jobs <status|serve>")
+	}
+
+	switch args[0] {
+	case "status":
+		return runJobsStatus(args[1:])
+	case "serve":
+		return runJobsServe(args[1:])
+	default:
+		return fmt.Errorf("unknown jobs subcommand %q", args[0])
+	}
+}
+
+func runJobsStatus(args []string) error {
+	fs := flag.NewFlagSet("jobs status", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: highlightctl jobs status <guid>")
+	}
+
+	h := handlers.NewHandlers()
+	job, err := h.JobStatus(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("error fetching job status: %w", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(job)
+}
+
+func runJobsServe(args []string) error {
+	fs := flag.NewFlagSet("jobs serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8090", "address to serve the job status endpoint on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	h := handlers.NewHandlers()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jobs/", h.GetJobHandler)
+
+	fmt.Fprintf(os.Stderr, "serving GET /jobs/{guid} on %s\n", *addr)
+	return http.ListenAndServe(*addr, mux)
+}
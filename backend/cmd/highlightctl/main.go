@@ -0,0 +1,40 @@
+// Command highlightctl is an operator CLI for one-off tasks like GDPR
+// session deletions, reusing the same handler packages as the Step
+// Function instead of round-tripping through a Lambda invocation.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "sessions":
+		err = runSessionsCommand(os.Args[2:])
+	case "jobs":
+		err = runJobsCommand(os.Args[2:])
+	default:
+		usage()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: highlightctl <command> [args]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  sessions delete   delete (or preview deleting) sessions matching a query")
+	fmt.Fprintln(os.Stderr, "  jobs status       print the status of a deletion job by GUID")
+	fmt.Fprintln(os.Stderr, "  jobs serve        serve GET /jobs/{guid} for polling deletion job status")
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/highlight-run/highlight/backend/lambda-functions/deleteSessions/handlers"
+	"github.com/highlight-run/highlight/backend/lambda-functions/deleteSessions/utils"
+)
+
+func runSessionsCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: highlightctl sessions <delete>")
+	}
+
+	switch args[0] {
+	case "delete":
+		return runSessionsDelete(args[1:])
+	default:
+		return fmt.Errorf("unknown sessions subcommand %q", args[0])
+	}
+}
+
+func runSessionsDelete(args []string) error {
+	fs := flag.NewFlagSet("sessions delete", flag.ExitOnError)
+	project := fs.Int("project", 0, "project ID to delete sessions from")
+	query := fs.String("query", "", "OpenSearch query string identifying sessions to delete")
+	dryRun := fs.Bool("dry-run", false, "report what would be deleted without deleting or queuing anything")
+	batchSize := fs.Int("batch-size", 10000, "number of sessions to scroll from OpenSearch per batch")
+	concurrency := fs.Int("concurrency", 1, "number of batches to delete concurrently")
+	format := fs.String("format", "table", "dry-run report format: table or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *project == 0 {
+		return fmt.Errorf("--project is required")
+	}
+	if *query == "" {
+		return fmt.Errorf("--query is required")
+	}
+	if *concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1, got %d", *concurrency)
+	}
+
+	ctx := context.Background()
+	h := handlers.NewHandlers()
+
+	if *dryRun {
+		fmt.Fprintf(os.Stderr, "running dry-run report for project %d, query %q\n", *project, *query)
+
+		report, err := h.DryRunSessionsQuery(ctx, *project, *query)
+		if err != nil {
+			return fmt.Errorf("error running dry-run report: %w", err)
+		}
+
+		return printDryRunReport(report, *format)
+	}
+
+	fmt.Fprintf(os.Stderr, "enumerating sessions for project %d, query %q\n", *project, *query)
+
+	batches, err := h.GetSessionIdsByQuery(ctx, utils.QuerySessionsInput{
+		ProjectId: *project,
+		Query:     *query,
+		BatchSize: *batchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("error enumerating sessions: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "enumerated %d batch(es), deleting with concurrency %d\n", len(batches), *concurrency)
+
+	sem := make(chan struct{}, *concurrency)
+	var wg sync.WaitGroup
+	errs := make([]error, len(batches))
+	for i, batch := range batches {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, batch utils.BatchIdResponse) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			fmt.Fprintf(os.Stderr, "deleting batch %d/%d (task=%s batch=%s)\n", i+1, len(batches), batch.TaskId, batch.BatchId)
+			errs[i] = deleteBatch(ctx, h, batch)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "done")
+	return nil
+}
+
+func deleteBatch(ctx context.Context, h handlers.Handlers, batch utils.BatchIdResponse) error {
+	if _, err := h.DeleteSessionBatchFromOpenSearch(ctx, batch); err != nil {
+		return fmt.Errorf("batch %s: %w", batch.BatchId, err)
+	}
+	if _, err := h.DeleteSessionBatchFromPostgres(ctx, batch); err != nil {
+		return fmt.Errorf("batch %s: %w", batch.BatchId, err)
+	}
+	if _, err := h.DeleteSessionBatchFromS3(ctx, batch); err != nil {
+		return fmt.Errorf("batch %s: %w", batch.BatchId, err)
+	}
+	return nil
+}
+
+func printDryRunReport(report *handlers.DryRunReport, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "table":
+		w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+		fmt.Fprintf(w, "BACKEND\tCOUNT\n")
+		fmt.Fprintf(w, "opensearch docs\t%d\n", report.OpenSearchDocs)
+		fmt.Fprintf(w, "postgres sessions\t%d\n", report.PostgresSessions)
+		fmt.Fprintf(w, "postgres session_fields\t%d\n", report.PostgresSessionFields)
+		fmt.Fprintf(w, "s3 objects\t%d\n", report.S3Objects)
+		fmt.Fprintf(w, "s3 total bytes\t%d\n", report.S3TotalBytes)
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown --format %q, want table or json", format)
+	}
+}
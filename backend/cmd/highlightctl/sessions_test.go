@@ -0,0 +1,87 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/lambda-functions/deleteSessions/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	orig := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+
+	require.NoError(t, w.Close())
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(out)
+}
+
+func TestPrintDryRunReport(t *testing.T) {
+	report := &handlers.DryRunReport{
+		OpenSearchDocs:        12,
+		PostgresSessions:      12,
+		PostgresSessionFields: 340,
+		S3Objects:             24,
+		S3TotalBytes:          104857600,
+	}
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{
+			format: "table",
+			want: []string{
+				"BACKEND", "COUNT",
+				"opensearch docs", "12",
+				"postgres sessions", "12",
+				"postgres session_fields", "340",
+				"s3 objects", "24",
+				"s3 total bytes", "104857600",
+			},
+		},
+		{
+			format: "json",
+			want: []string{
+				`"opensearch_docs": 12`,
+				`"postgres_sessions": 12`,
+				`"postgres_session_fields": 340`,
+				`"s3_objects": 24`,
+				`"s3_total_bytes": 104857600`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var err error
+			out := captureStdout(t, func() {
+				err = printDryRunReport(report, tt.format)
+			})
+			require.NoError(t, err)
+
+			for _, want := range tt.want {
+				assert.Contains(t, out, want)
+			}
+		})
+	}
+}
+
+func TestPrintDryRunReportUnknownFormat(t *testing.T) {
+	err := printDryRunReport(&handlers.DryRunReport{}, "yaml")
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "yaml"))
+}
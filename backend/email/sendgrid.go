@@ -0,0 +1,57 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+// sendGridTemplateIDs maps a transport-agnostic template name to the
+// SendGrid dynamic template ID that renders it.
+var sendGridTemplateIDs = map[string]string{
+	TemplateSessionsDeleted: SessionsDeletedEmailTemplateID,
+}
+
+// SendGridTransport is an email.Transport backed by SendGrid dynamic
+// templates.
+type SendGridTransport struct {
+	client *sendgrid.Client
+}
+
+func NewSendGridTransport() *SendGridTransport {
+	return &SendGridTransport{client: sendgrid.NewSendClient(os.Getenv("SENDGRID_API_KEY"))}
+}
+
+func (t *SendGridTransport) SendTemplated(ctx context.Context, to string, templateName string, data map[string]any) error {
+	templateID, ok := sendGridTemplateIDs[templateName]
+	if !ok {
+		return errors.Errorf("no SendGrid template configured for %q", templateName)
+	}
+
+	m := mail.NewV3Mail()
+	m.SetFrom(mail.NewEmail("Highlight", SendGridOutboundEmail))
+	m.SetTemplateID(templateID)
+
+	p := mail.NewPersonalization()
+	p.AddTos(&mail.Email{Address: to})
+	for key, value := range data {
+		p.SetDynamicTemplateData(key, value)
+	}
+	m.AddPersonalizations(p)
+
+	resp, err := t.client.Send(m)
+	if err != nil || resp.StatusCode >= 300 {
+		estr := "error sending sendgrid email -> "
+		estr += fmt.Sprintf("resp-code: %v; ", resp)
+		if err != nil {
+			estr += fmt.Sprintf("err: %v", err.Error())
+		}
+		return errors.New(estr)
+	}
+
+	return nil
+}
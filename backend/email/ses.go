@@ -0,0 +1,51 @@
+package email
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/pkg/errors"
+)
+
+// SESTransport is an email.Transport backed by AWS SES, rendering bodies
+// from local templates since SES has no first-class dynamic templates.
+type SESTransport struct {
+	client *sesv2.Client
+}
+
+func NewSESTransport(ctx context.Context) (*SESTransport, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion("us-west-2"))
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading default config")
+	}
+
+	return &SESTransport{client: sesv2.NewFromConfig(cfg)}, nil
+}
+
+func (t *SESTransport) SendTemplated(ctx context.Context, to string, templateName string, data map[string]any) error {
+	body, err := renderTemplate(templateName, data)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(SendGridOutboundEmail),
+		Destination:      &types.Destination{ToAddresses: []string{to}},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: aws.String(subjectForTemplate(templateName))},
+				Body: &types.Body{
+					Html: &types.Content{Data: aws.String(body)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "error sending SES email")
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SMTPTransport is an email.Transport that sends mail through a generic
+// SMTP server, for self-hosted deployments without SendGrid or SES.
+type SMTPTransport struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPTransport() (*SMTPTransport, error) {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return nil, errors.New("SMTP_HOST must be set when EMAIL_PROVIDER=smtp")
+	}
+
+	return &SMTPTransport{
+		host:     host,
+		port:     envOrDefault("SMTP_PORT", "587"),
+		username: os.Getenv("SMTP_USERNAME"),
+		password: os.Getenv("SMTP_PASSWORD"),
+		from:     envOrDefault("SMTP_FROM", SendGridOutboundEmail),
+	}, nil
+}
+
+func (t *SMTPTransport) SendTemplated(ctx context.Context, to string, templateName string, data map[string]any) error {
+	if err := validateHeaderValue(to); err != nil {
+		return errors.Wrap(err, "invalid To address")
+	}
+	if err := validateHeaderValue(t.from); err != nil {
+		return errors.Wrap(err, "invalid From address")
+	}
+
+	body, err := renderTemplate(templateName, data)
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s",
+		t.from, to, subjectForTemplate(templateName), body)
+
+	var auth smtp.Auth
+	if t.username != "" {
+		auth = smtp.PlainAuth("", t.username, t.password, t.host)
+	}
+
+	addr := fmt.Sprintf("%s:%s", t.host, t.port)
+	if err := smtp.SendMail(addr, auth, t.from, []string{to}, []byte(msg)); err != nil {
+		return errors.Wrap(err, "error sending SMTP email")
+	}
+
+	return nil
+}
+
+// validateHeaderValue rejects CR/LF in a value that will be interpolated
+// directly into a raw MIME header, so a stray newline in an address field
+// can't be used to splice extra headers (e.g. Bcc) into outbound mail.
+func validateHeaderValue(s string) error {
+	if strings.ContainsAny(s, "\r\n") {
+		return errors.New("header value must not contain CR or LF")
+	}
+	return nil
+}
+
+func envOrDefault(key string, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
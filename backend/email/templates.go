@@ -0,0 +1,52 @@
+package email
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// templateDir is the directory local Go templates are loaded from for the
+// SES and SMTP transports. It defaults to templates/, resolved relative to
+// the process's working directory, and can be overridden with
+// EMAIL_TEMPLATE_DIR for self-hosted deployments with a custom layout.
+var templateDir = "templates"
+
+func init() {
+	if dir := os.Getenv("EMAIL_TEMPLATE_DIR"); dir != "" {
+		templateDir = dir
+	}
+}
+
+// templateSubjects maps a template name to the subject line used by the
+// SES and SMTP transports. SendGrid's dynamic templates carry their own
+// subject, so this is only consulted outside of SendGridTransport.
+var templateSubjects = map[string]string{
+	TemplateSessionsDeleted: "Your Highlight sessions have been deleted",
+}
+
+func subjectForTemplate(templateName string) string {
+	if subject, ok := templateSubjects[templateName]; ok {
+		return subject
+	}
+	return "Highlight notification"
+}
+
+func renderTemplate(templateName string, data map[string]any) (string, error) {
+	path := filepath.Join(templateDir, templateName+".tmpl")
+
+	t, err := template.ParseFiles(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "error parsing email template %q", path)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(err, "error executing email template %q", path)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,49 @@
+package email
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// SendGridOutboundEmail is the address that outbound notification emails
+// are sent from.
+const SendGridOutboundEmail = "notifications@highlight.io"
+
+// SessionsDeletedEmailTemplateID is the SendGrid dynamic template used for
+// the "sessions deleted" notification.
+const SessionsDeletedEmailTemplateID = "d-0000000000000000000000000000000"
+
+// TemplateSessionsDeleted is the transport-agnostic name of the "sessions
+// deleted" notification template. SendGrid maps it to a dynamic template
+// ID; SES and SMTP render it from templates/ on disk.
+const TemplateSessionsDeleted = "sessions_deleted"
+
+// Transport sends a templated transactional email. Implementations are
+// provided for SendGrid, AWS SES, and generic SMTP so self-hosted
+// deployments without a SendGrid account can still deliver notifications.
+type Transport interface {
+	SendTemplated(ctx context.Context, to string, templateName string, data map[string]any) error
+}
+
+// NewTransportFromEnv builds a Transport from the EMAIL_PROVIDER
+// environment variable, defaulting to SendGrid so existing deployments
+// keep working without any configuration changes.
+func NewTransportFromEnv(ctx context.Context) (Transport, error) {
+	provider := os.Getenv("EMAIL_PROVIDER")
+	if provider == "" {
+		provider = "sendgrid"
+	}
+
+	switch provider {
+	case "sendgrid":
+		return NewSendGridTransport(), nil
+	case "ses":
+		return NewSESTransport(ctx)
+	case "smtp":
+		return NewSMTPTransport()
+	default:
+		return nil, errors.Errorf("unknown EMAIL_PROVIDER %q", provider)
+	}
+}
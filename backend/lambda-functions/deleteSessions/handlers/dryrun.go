@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/opensearch"
+	"github.com/pkg/errors"
+)
+
+// DryRunReport summarizes what a session-deletion query would touch,
+// without deleting or queuing anything, so operators can preview a GDPR
+// deletion before running it for real.
+type DryRunReport struct {
+	OpenSearchDocs        int   `json:"opensearch_docs"`
+	PostgresSessions      int   `json:"postgres_sessions"`
+	PostgresSessionFields int   `json:"postgres_session_fields"`
+	S3Objects             int   `json:"s3_objects"`
+	S3TotalBytes          int64 `json:"s3_total_bytes"`
+}
+
+// DryRunSessionsQuery enumerates the sessions matching query without
+// creating any DeleteSessionsTask rows, then tallies the rows/objects each
+// backend holds for them.
+func (h *handlers) DryRunSessionsQuery(ctx context.Context, projectId int, query string) (*DryRunReport, error) {
+	sessionIds, err := h.scrollSessionIds(ctx, projectId, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "error enumerating sessions")
+	}
+
+	report := &DryRunReport{OpenSearchDocs: len(sessionIds)}
+	if len(sessionIds) == 0 {
+		return report, nil
+	}
+
+	if err := h.db.Raw(`
+		SELECT count(*) FROM sessions
+		WHERE id in (?)
+	`, sessionIds).Scan(&report.PostgresSessions).Error; err != nil {
+		return nil, errors.Wrap(err, "error counting sessions")
+	}
+
+	if err := h.db.Raw(`
+		SELECT count(*) FROM session_fields
+		WHERE session_id in (?)
+	`, sessionIds).Scan(&report.PostgresSessionFields).Error; err != nil {
+		return nil, errors.Wrap(err, "error counting session fields")
+	}
+
+	for _, sessionId := range sessionIds {
+		objects, err := h.payloadStore.ListSessionObjects(ctx, projectId, sessionId)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing session objects")
+		}
+
+		report.S3Objects += len(objects)
+		for _, object := range objects {
+			report.S3TotalBytes += object.Size
+		}
+	}
+
+	return report, nil
+}
+
+// scrollSessionIds walks every page of the given OpenSearch query and
+// returns the matched session IDs, with no side effects.
+func (h *handlers) scrollSessionIds(ctx context.Context, projectId int, query string) ([]int, error) {
+	var sessionIds []int
+	lastId := 0
+	for {
+		options := opensearch.SearchOptions{
+			MaxResults:    ptr.Int(10000),
+			SortField:     ptr.String("id"),
+			SortOrder:     ptr.String("asc"),
+			IncludeFields: []string{"id"},
+		}
+		if lastId != 0 {
+			options.SearchAfter = []interface{}{lastId}
+		}
+
+		results := []model.Session{}
+		_, _, err := h.opensearchClient.Search([]opensearch.Index{opensearch.IndexSessions}, projectId, query, options, &results)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(results) == 0 {
+			break
+		}
+
+		for _, r := range results {
+			sessionIds = append(sessionIds, r.ID)
+		}
+		lastId = results[len(results)-1].ID
+	}
+
+	return sessionIds, nil
+}
@@ -2,12 +2,10 @@ package handlers
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"os"
+	"strconv"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/smithy-go/ptr"
 	"github.com/google/uuid"
 	"github.com/highlight-run/highlight/backend/email"
@@ -15,11 +13,10 @@ import (
 	"github.com/highlight-run/highlight/backend/model"
 	storage "github.com/highlight-run/highlight/backend/object-storage"
 	"github.com/highlight-run/highlight/backend/opensearch"
-	"github.com/highlight-run/highlight/backend/util"
+	"github.com/highlight-run/highlight/backend/presenter"
 	"github.com/pkg/errors"
-	"github.com/sendgrid/sendgrid-go"
-	"github.com/sendgrid/sendgrid-go/helpers/mail"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Handlers interface {
@@ -28,21 +25,23 @@ type Handlers interface {
 	DeleteSessionBatchFromS3(context.Context, utils.BatchIdResponse) (*utils.BatchIdResponse, error)
 	GetSessionIdsByQuery(context.Context, utils.QuerySessionsInput) ([]utils.BatchIdResponse, error)
 	SendEmail(context.Context, utils.QuerySessionsInput) error
+	DryRunSessionsQuery(ctx context.Context, projectId int, query string) (*DryRunReport, error)
+	JobStatus(guid string) (*presenter.Job, error)
 }
 
 type handlers struct {
 	db               *gorm.DB
 	opensearchClient *opensearch.Client
-	s3Client         *s3.Client
-	sendgridClient   *sendgrid.Client
+	payloadStore     storage.SessionPayloadStore
+	emailTransport   email.Transport
 }
 
-func InitHandlers(db *gorm.DB, opensearchClient *opensearch.Client, s3Client *s3.Client, sendgridClient *sendgrid.Client) *handlers {
+func InitHandlers(db *gorm.DB, opensearchClient *opensearch.Client, payloadStore storage.SessionPayloadStore, emailTransport email.Transport) *handlers {
 	return &handlers{
 		db:               db,
 		opensearchClient: opensearchClient,
-		s3Client:         s3Client,
-		sendgridClient:   sendgridClient,
+		payloadStore:     payloadStore,
+		emailTransport:   emailTransport,
 	}
 }
 
@@ -57,17 +56,17 @@ func NewHandlers() *handlers {
 		log.Fatal(errors.Wrap(err, "error creating opensearch client"))
 	}
 
-	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion("us-west-2"))
+	payloadStore, err := storage.NewSessionPayloadStoreFromEnv(context.Background())
 	if err != nil {
-		log.Fatal(errors.Wrap(err, "error loading default from config"))
+		log.Fatal(errors.Wrap(err, "error creating session payload store"))
 	}
-	s3Client := s3.NewFromConfig(cfg, func(o *s3.Options) {
-		o.UsePathStyle = true
-	})
 
-	sendgridClient := sendgrid.NewSendClient(os.Getenv("SENDGRID_API_KEY"))
+	emailTransport, err := email.NewTransportFromEnv(context.Background())
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "error creating email transport"))
+	}
 
-	return InitHandlers(db, opensearchClient, s3Client, sendgridClient)
+	return InitHandlers(db, opensearchClient, payloadStore, emailTransport)
 }
 
 func (h *handlers) DeleteSessionBatchFromOpenSearch(ctx context.Context, event utils.BatchIdResponse) (*utils.BatchIdResponse, error) {
@@ -79,6 +78,7 @@ func (h *handlers) DeleteSessionBatchFromOpenSearch(ctx context.Context, event u
 	for _, sessionId := range sessionIds {
 		if !event.DryRun {
 			if err := h.opensearchClient.Delete(opensearch.IndexSessions, sessionId); err != nil {
+				h.failJob(event.JobGUID, err)
 				return nil, errors.Wrap(err, "error creating bulk delete request")
 			}
 		}
@@ -86,6 +86,10 @@ func (h *handlers) DeleteSessionBatchFromOpenSearch(ctx context.Context, event u
 
 	h.opensearchClient.Close()
 
+	if err := h.checkpointJob(event.JobGUID, model.DeletionJobPhaseDeletingPG, len(sessionIds)); err != nil {
+		return nil, errors.Wrap(err, "error checkpointing deletion job")
+	}
+
 	return &event, nil
 }
 
@@ -100,6 +104,7 @@ func (h *handlers) DeleteSessionBatchFromPostgres(ctx context.Context, event uti
 			DELETE FROM session_fields
 			WHERE session_id in (?)
 		`, sessionIds).Error; err != nil {
+			h.failJob(event.JobGUID, err)
 			return nil, errors.Wrap(err, "error deleting session fields")
 		}
 
@@ -107,10 +112,15 @@ func (h *handlers) DeleteSessionBatchFromPostgres(ctx context.Context, event uti
 			DELETE FROM sessions
 			WHERE id in (?)
 		`, sessionIds).Error; err != nil {
+			h.failJob(event.JobGUID, err)
 			return nil, errors.Wrap(err, "error deleting sessions")
 		}
 	}
 
+	if err := h.checkpointJob(event.JobGUID, model.DeletionJobPhaseDeletingS3, len(sessionIds)); err != nil {
+		return nil, errors.Wrap(err, "error checkpointing deletion job")
+	}
+
 	return &event, nil
 }
 
@@ -121,48 +131,53 @@ func (h *handlers) DeleteSessionBatchFromS3(ctx context.Context, event utils.Bat
 	}
 
 	for _, sessionId := range sessionIds {
-		devStr := ""
-		if util.IsDevOrTestEnv() {
-			devStr = "dev/"
-		}
-
-		prefix := fmt.Sprintf("%s%d/%d/", devStr, event.ProjectId, sessionId)
-		options := s3.ListObjectsV2Input{
-			Bucket: &storage.S3SessionsPayloadBucketName,
-			Prefix: &prefix,
-		}
-		output, err := h.s3Client.ListObjectsV2(ctx, &options)
+		objects, err := h.payloadStore.ListSessionObjects(ctx, event.ProjectId, sessionId)
 		if err != nil {
-			return nil, errors.Wrap(err, "error listing objects in S3")
+			h.failJob(event.JobGUID, err)
+			return nil, errors.Wrap(err, "error listing session objects")
 		}
 
-		for _, object := range output.Contents {
-			options := s3.DeleteObjectInput{
-				Bucket: &storage.S3SessionsPayloadBucketName,
-				Key:    object.Key,
-			}
-			if !event.DryRun {
-				_, err := h.s3Client.DeleteObject(ctx, &options)
-				if err != nil {
-					return nil, errors.Wrap(err, "error deleting objects from S3")
-				}
+		if !event.DryRun {
+			if err := h.payloadStore.DeleteObjects(ctx, objects); err != nil {
+				h.failJob(event.JobGUID, err)
+				return nil, errors.Wrap(err, "error deleting session objects")
 			}
 		}
 	}
 
+	if err := h.completeJobBatch(event.JobGUID, len(sessionIds)); err != nil {
+		return nil, errors.Wrap(err, "error checkpointing deletion job")
+	}
+
 	return &event, nil
 }
 
 func (h *handlers) GetSessionIdsByQuery(ctx context.Context, event utils.QuerySessionsInput) ([]utils.BatchIdResponse, error) {
-	taskId := uuid.New().String()
+	job, err := h.loadOrCreateDeletionJob(event)
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading deletion job")
+	}
+	taskId := job.GUID
+
 	lastId := 0
+	if job.SearchAfter != "" {
+		if parsed, err := strconv.Atoi(job.SearchAfter); err == nil {
+			lastId = parsed
+		}
+	}
+
+	batchSize := event.BatchSize
+	if batchSize <= 0 {
+		batchSize = 10000
+	}
+
 	responses := []utils.BatchIdResponse{}
 	for {
 		batchId := uuid.New().String()
 		toDelete := []model.DeleteSessionsTask{}
 
 		options := opensearch.SearchOptions{
-			MaxResults:    ptr.Int(10000),
+			MaxResults:    ptr.Int(batchSize),
 			SortField:     ptr.String("id"),
 			SortOrder:     ptr.String("asc"),
 			IncludeFields: []string{"id"},
@@ -175,6 +190,7 @@ func (h *handlers) GetSessionIdsByQuery(ctx context.Context, event utils.QuerySe
 		_, _, err := h.opensearchClient.Search([]opensearch.Index{opensearch.IndexSessions},
 			event.ProjectId, event.Query, options, &results)
 		if err != nil {
+			h.failJob(job.GUID, err)
 			return nil, err
 		}
 
@@ -191,8 +207,20 @@ func (h *handlers) GetSessionIdsByQuery(ctx context.Context, event utils.QuerySe
 			})
 		}
 
-		if err := h.db.Create(&toDelete).Error; err != nil {
-			return nil, errors.Wrap(err, "error saving DeleteSessionsTasks")
+		if err := h.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&toDelete).Error; err != nil {
+				return errors.Wrap(err, "error saving DeleteSessionsTasks")
+			}
+
+			return tx.Model(&model.DeletionJob{}).
+				Where("guid = ?", job.GUID).
+				Updates(map[string]interface{}{
+					"sessions_total": gorm.Expr("sessions_total + ?", len(toDelete)),
+					"batches_total":  gorm.Expr("batches_total + 1"),
+					"search_after":   strconv.Itoa(lastId),
+				}).Error
+		}); err != nil {
+			return nil, errors.Wrap(err, "error checkpointing deletion job")
 		}
 
 		responses = append(responses, utils.BatchIdResponse{
@@ -200,34 +228,127 @@ func (h *handlers) GetSessionIdsByQuery(ctx context.Context, event utils.QuerySe
 			TaskId:    taskId,
 			BatchId:   batchId,
 			DryRun:    event.DryRun,
+			JobGUID:   job.GUID,
 		})
 	}
 
+	if err := h.db.Model(&model.DeletionJob{}).
+		Where("guid = ?", job.GUID).
+		Update("phase", model.DeletionJobPhaseDeletingOS).Error; err != nil {
+		return nil, errors.Wrap(err, "error advancing deletion job phase")
+	}
+
 	return responses, nil
 }
 
-func (h *handlers) SendEmail(ctx context.Context, event utils.QuerySessionsInput) error {
-	to := &mail.Email{Address: event.Email}
+// loadOrCreateDeletionJob resumes an existing DeletionJob when
+// event.JobGUID is set, or creates a new one in the enumerating phase
+// otherwise.
+func (h *handlers) loadOrCreateDeletionJob(event utils.QuerySessionsInput) (*model.DeletionJob, error) {
+	if event.JobGUID != "" {
+		var job model.DeletionJob
+		if err := h.db.Where(&model.DeletionJob{GUID: event.JobGUID}).First(&job).Error; err != nil {
+			return nil, errors.Wrap(err, "error loading existing deletion job")
+		}
+		return &job, nil
+	}
 
-	m := mail.NewV3Mail()
-	from := mail.NewEmail("Highlight", email.SendGridOutboundEmail)
-	m.SetFrom(from)
-	m.SetTemplateID(email.SessionsDeletedEmailTemplateID)
+	job := model.DeletionJob{
+		GUID:      uuid.New().String(),
+		ProjectID: event.ProjectId,
+		Query:     event.Query,
+		DryRun:    event.DryRun,
+		Phase:     model.DeletionJobPhaseEnumerating,
+	}
+	if err := h.db.Create(&job).Error; err != nil {
+		return nil, errors.Wrap(err, "error creating deletion job")
+	}
+
+	return &job, nil
+}
+
+// checkpointJob moves the job to the given phase (the one the batch enters
+// next) and adds processed to sessions_done. It is a no-op when the event
+// doesn't carry a job GUID (e.g. pre-existing invocations during rollout).
+func (h *handlers) checkpointJob(guid string, phase model.DeletionJobPhase, processed int) error {
+	if guid == "" {
+		return nil
+	}
+
+	return h.db.Model(&model.DeletionJob{}).
+		Where("guid = ?", guid).
+		Updates(map[string]interface{}{
+			"phase":         phase,
+			"sessions_done": gorm.Expr("sessions_done + ?", processed),
+		}).Error
+}
+
+// completeJobBatch records that one batch finished the S3 phase and, only
+// once every batch enumerated for this job has done the same, transitions
+// the job to the complete phase. Batches run concurrently (Step Function
+// Map state, or highlightctl's --concurrency fan-out), so the job can't
+// simply be marked complete by whichever batch happens to finish S3 first
+// — it locks the row to serialize the read-modify-write against sibling
+// batches finishing at the same time.
+func (h *handlers) completeJobBatch(guid string, processed int) error {
+	if guid == "" {
+		return nil
+	}
+
+	return h.db.Transaction(func(tx *gorm.DB) error {
+		var job model.DeletionJob
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(&model.DeletionJob{GUID: guid}).First(&job).Error; err != nil {
+			return err
+		}
+
+		batchesS3Done := job.BatchesS3Done + 1
+		updates := map[string]interface{}{
+			"sessions_done":   job.SessionsDone + processed,
+			"batches_s3_done": batchesS3Done,
+		}
+		if batchesS3Done >= job.BatchesTotal {
+			updates["phase"] = model.DeletionJobPhaseComplete
+		}
+
+		return tx.Model(&model.DeletionJob{}).Where("guid = ?", guid).Updates(updates).Error
+	})
+}
 
-	p := mail.NewPersonalization()
-	p.AddTos(to)
-	p.SetDynamicTemplateData("First_Name", event.FirstName)
-	p.SetDynamicTemplateData("Session_Count", event.SessionCount)
+// failJob marks a job as failed and appends the triggering error, best
+// effort. Callers still propagate the original error to the Step Function.
+func (h *handlers) failJob(guid string, cause error) {
+	if guid == "" {
+		return
+	}
+
+	_ = h.db.Model(&model.DeletionJob{}).
+		Where("guid = ?", guid).
+		Updates(map[string]interface{}{
+			"phase":  model.DeletionJobPhaseFailed,
+			"errors": gorm.Expr("array_append(errors, ?)", cause.Error()),
+		}).Error
+}
 
-	m.AddPersonalizations(p)
-	if resp, sendGridErr := h.sendgridClient.Send(m); sendGridErr != nil || resp.StatusCode >= 300 {
-		estr := "error sending sendgrid email -> "
-		estr += fmt.Sprintf("resp-code: %v; ", resp)
-		if sendGridErr != nil {
-			estr += fmt.Sprintf("err: %v", sendGridErr.Error())
+func (h *handlers) SendEmail(ctx context.Context, event utils.QuerySessionsInput) error {
+	if event.JobGUID != "" {
+		var job model.DeletionJob
+		if err := h.db.Where(&model.DeletionJob{GUID: event.JobGUID}).First(&job).Error; err != nil {
+			return errors.Wrap(err, "error loading deletion job")
+		}
+		if job.Phase != model.DeletionJobPhaseComplete {
+			return nil
 		}
-		return errors.New(estr)
+	}
+
+	data := map[string]any{
+		"First_Name":    event.FirstName,
+		"Session_Count": event.SessionCount,
+	}
+
+	if err := h.emailTransport.SendTemplated(ctx, event.Email, email.TemplateSessionsDeleted, data); err != nil {
+		return errors.Wrap(err, "error sending sessions deleted email")
 	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/lambda-functions/deleteSessions/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransport is an email.Transport that captures the rendered payload
+// instead of sending it, for use in tests.
+type fakeTransport struct {
+	to           string
+	templateName string
+	data         map[string]any
+}
+
+func (f *fakeTransport) SendTemplated(ctx context.Context, to string, templateName string, data map[string]any) error {
+	f.to = to
+	f.templateName = templateName
+	f.data = data
+	return nil
+}
+
+func TestSendEmail(t *testing.T) {
+	transport := &fakeTransport{}
+	h := &handlers{emailTransport: transport}
+
+	event := utils.QuerySessionsInput{
+		Email:        "user@example.com",
+		FirstName:    "Jamie",
+		SessionCount: 42,
+	}
+
+	require.NoError(t, h.SendEmail(context.Background(), event))
+
+	assert.Equal(t, "user@example.com", transport.to)
+	assert.Equal(t, "sessions_deleted", transport.templateName)
+	assert.Equal(t, "Jamie", transport.data["First_Name"])
+	assert.Equal(t, 42, transport.data["Session_Count"])
+}
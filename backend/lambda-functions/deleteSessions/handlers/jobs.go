@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/highlight-run/highlight/backend/presenter"
+)
+
+// GetJobHandler serves GET /jobs/{guid}, returning the presenter.Job
+// payload for a deletion job so operators can poll its progress without
+// querying Postgres directly.
+func (h *handlers) GetJobHandler(w http.ResponseWriter, r *http.Request) {
+	guid := strings.TrimPrefix(r.URL.Path, "/jobs/")
+	if guid == "" || guid == r.URL.Path {
+		http.Error(w, "guid is required", http.StatusBadRequest)
+		return
+	}
+
+	job, err := presenter.JobFromGUID(h.db, guid)
+	if err != nil {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(job)
+}
+
+// JobStatus returns the presenter representation of a deletion job by its
+// GUID. It backs the highlightctl "jobs status" subcommand, which reads job
+// progress directly rather than going through the HTTP endpoint.
+func (h *handlers) JobStatus(guid string) (*presenter.Job, error) {
+	return presenter.JobFromGUID(h.db, guid)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/highlight-run/highlight/backend/presenter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJobHandler(t *testing.T) {
+	db, mock := newMockDB(t)
+	h := &handlers{db: db}
+
+	guid := "11111111-1111-1111-1111-111111111111"
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "guid", "project_id", "query", "dry_run", "phase", "sessions_total", "sessions_done", "batches_total", "batches_s3_done", "search_after", "errors"}).
+		AddRow(1, time.Time{}, time.Time{}, guid, 7, "browser=chrome", false, "deleting_s3", 100, 40, 2, 1, "12345", "{}")
+	mock.ExpectQuery(`SELECT \* FROM "deletion_jobs"`).WillReturnRows(rows)
+
+	req := httptest.NewRequest(http.MethodGet, "/jobs/"+guid, nil)
+	rec := httptest.NewRecorder()
+
+	h.GetJobHandler(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got presenter.Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, guid, got.GUID)
+	assert.Equal(t, 7, got.ProjectID)
+	assert.Equal(t, "deleting_s3", got.Phase)
+	assert.Equal(t, 100, got.SessionsTotal)
+	assert.Equal(t, 40, got.SessionsDone)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetJobHandlerMissingGUID(t *testing.T) {
+	db, _ := newMockDB(t)
+	h := &handlers{db: db}
+
+	req := httptest.NewRequest(http.MethodGet, "/jobs/", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetJobHandler(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
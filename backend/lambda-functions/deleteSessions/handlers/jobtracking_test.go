@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/highlight-run/highlight/backend/lambda-functions/deleteSessions/utils"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func deletionJobRows(guid string, phase model.DeletionJobPhase, searchAfter string, batchesTotal, batchesS3Done int) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "created_at", "updated_at", "guid", "project_id", "query", "dry_run", "phase", "sessions_total", "sessions_done", "batches_total", "batches_s3_done", "search_after", "errors"}).
+		AddRow(1, time.Time{}, time.Time{}, guid, 7, "browser=chrome", false, phase, 100, 40, batchesTotal, batchesS3Done, searchAfter, "{}")
+}
+
+func TestLoadOrCreateDeletionJobResumesFromSearchAfter(t *testing.T) {
+	db, mock := newMockDB(t)
+	h := &handlers{db: db}
+
+	guid := "11111111-1111-1111-1111-111111111111"
+	mock.ExpectQuery(`SELECT \* FROM "deletion_jobs"`).
+		WillReturnRows(deletionJobRows(guid, model.DeletionJobPhaseDeletingOS, "98765", 2, 1))
+
+	job, err := h.loadOrCreateDeletionJob(utils.QuerySessionsInput{JobGUID: guid})
+	require.NoError(t, err)
+
+	assert.Equal(t, guid, job.GUID)
+	assert.Equal(t, "98765", job.SearchAfter)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadOrCreateDeletionJobCreatesNew(t *testing.T) {
+	db, mock := newMockDB(t)
+	h := &handlers{db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "deletion_jobs"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	job, err := h.loadOrCreateDeletionJob(utils.QuerySessionsInput{ProjectId: 7, Query: "browser=chrome"})
+	require.NoError(t, err)
+
+	assert.Equal(t, model.DeletionJobPhaseEnumerating, job.Phase)
+	assert.NotEmpty(t, job.GUID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompleteJobBatchOnlyCompletesOnLastBatch(t *testing.T) {
+	db, mock := newMockDB(t)
+	h := &handlers{db: db}
+
+	guid := "11111111-1111-1111-1111-111111111111"
+
+	// First of two batches finishes: batches_s3_done goes from 0 to 1,
+	// short of batches_total, so the phase must not change to complete.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "deletion_jobs".+FOR UPDATE`).
+		WillReturnRows(deletionJobRows(guid, model.DeletionJobPhaseDeletingS3, "12345", 2, 0))
+	mock.ExpectExec(`UPDATE "deletion_jobs" SET`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, h.completeJobBatch(guid, 25))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Second (and final) batch finishes: batches_s3_done reaches
+	// batches_total, so this call must flip the phase to complete. gorm
+	// sets map columns in sorted order (batches_s3_done, phase,
+	// sessions_done), then updated_at, then the guid in the WHERE clause.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "deletion_jobs".+FOR UPDATE`).
+		WillReturnRows(deletionJobRows(guid, model.DeletionJobPhaseDeletingS3, "12345", 2, 1))
+	mock.ExpectExec(`UPDATE "deletion_jobs" SET`).
+		WithArgs(sqlmock.AnyArg(), string(model.DeletionJobPhaseComplete), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, h.completeJobBatch(guid, 15))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckpointJobUpdatesPhaseAndSessionsDone(t *testing.T) {
+	db, mock := newMockDB(t)
+	h := &handlers{db: db}
+
+	guid := "11111111-1111-1111-1111-111111111111"
+	mock.ExpectExec(`UPDATE "deletion_jobs" SET`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, h.checkpointJob(guid, model.DeletionJobPhaseDeletingPG, 10))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckpointJobNoopWithoutGUID(t *testing.T) {
+	db, _ := newMockDB(t)
+	h := &handlers{db: db}
+
+	require.NoError(t, h.checkpointJob("", model.DeletionJobPhaseDeletingPG, 10))
+}
+
+func TestFailJobUpdatesPhaseAndErrors(t *testing.T) {
+	db, mock := newMockDB(t)
+	h := &handlers{db: db}
+
+	guid := "11111111-1111-1111-1111-111111111111"
+	mock.ExpectExec(`UPDATE "deletion_jobs" SET`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	h.failJob(guid, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newMockDB returns a *gorm.DB backed by a sqlmock connection, along with
+// the mock.Sqlmock used to set expectations on it. Callers must satisfy
+// every expectation they set (mock.ExpectationsWereMet()) before the test
+// ends.
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       sqlDB,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return db, mock
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// QuerySessionsInput is the Step Function input that kicks off a deletion
+// run: which project and OpenSearch query to enumerate, whether to dry-run,
+// and who to notify once it completes. JobGUID resumes an existing
+// DeletionJob instead of starting a new one.
+type QuerySessionsInput struct {
+	ProjectId int
+	Query     string
+	DryRun    bool
+	Email     string
+	FirstName string
+	// SessionCount is the number of sessions deleted, populated once
+	// enumeration completes, for use in the "sessions deleted" email.
+	SessionCount int
+	JobGUID      string
+	// BatchSize overrides the number of sessions scrolled from OpenSearch
+	// per batch. Defaults to 10000 when zero.
+	BatchSize int
+}
+
+// BatchIdResponse identifies one batch of DeleteSessionsTask rows to be
+// deleted from OpenSearch, Postgres, and object storage.
+type BatchIdResponse struct {
+	ProjectId int
+	TaskId    string
+	BatchId   string
+	DryRun    bool
+	JobGUID   string
+}
+
+// GetSessionIdsInBatch returns the session IDs queued for deletion under a
+// given task/batch pair.
+func GetSessionIdsInBatch(db *gorm.DB, taskId string, batchId string) ([]int, error) {
+	var tasks []model.DeleteSessionsTask
+	if err := db.Where(&model.DeleteSessionsTask{TaskID: taskId, BatchID: batchId}).Find(&tasks).Error; err != nil {
+		return nil, errors.Wrap(err, "error querying DeleteSessionsTasks")
+	}
+
+	sessionIds := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		sessionIds = append(sessionIds, task.SessionID)
+	}
+
+	return sessionIds, nil
+}
@@ -0,0 +1,49 @@
+package model
+
+import "github.com/lib/pq"
+
+// DeletionJobPhase tracks which stage of a session-deletion run a
+// DeletionJob is currently in.
+type DeletionJobPhase string
+
+const (
+	DeletionJobPhaseEnumerating DeletionJobPhase = "enumerating"
+	DeletionJobPhaseDeletingOS  DeletionJobPhase = "deleting_os"
+	DeletionJobPhaseDeletingPG  DeletionJobPhase = "deleting_pg"
+	DeletionJobPhaseDeletingS3  DeletionJobPhase = "deleting_s3"
+	DeletionJobPhaseComplete    DeletionJobPhase = "complete"
+	DeletionJobPhaseFailed      DeletionJobPhase = "failed"
+)
+
+// DeletionJob is the durable record of a single "delete sessions matching
+// this query" request. Each handler in the deleteSessions Step Function
+// updates its counters and SearchAfter cursor as it makes progress, so a
+// re-invocation after a failure resumes from the last successful batch
+// instead of re-scrolling OpenSearch from the beginning.
+type DeletionJob struct {
+	Model
+	GUID      string `gorm:"uniqueIndex"`
+	ProjectID int
+	Query     string
+	DryRun    bool
+	Phase     DeletionJobPhase
+
+	SessionsTotal int
+	SessionsDone  int
+
+	// BatchesTotal is the number of batches GetSessionIdsByQuery has
+	// enumerated for this job. BatchesS3Done is how many of those batches
+	// have finished the S3 phase. The job only transitions to the complete
+	// phase once BatchesS3Done reaches BatchesTotal, since batches run
+	// concurrently and the S3 step is the last one each batch completes.
+	BatchesTotal  int
+	BatchesS3Done int
+
+	// SearchAfter is the last session ID of the last successfully
+	// enumerated batch, as a decimal string. It is passed back to
+	// OpenSearch as the search_after cursor to resume enumeration without
+	// rescanning from the beginning.
+	SearchAfter string
+
+	Errors pq.StringArray `gorm:"type:text[]"`
+}
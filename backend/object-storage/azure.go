@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/pkg/errors"
+)
+
+// AzureStore is a SessionPayloadStore backed by an Azure Blob Storage
+// container.
+type AzureStore struct {
+	client    *azblob.Client
+	container string
+}
+
+// NewAzureStore creates an AzureStore from AZURE_STORAGE_CONNECTION_STRING
+// and AZURE_STORAGE_CONTAINER_NAME environment variables.
+func NewAzureStore(ctx context.Context) (*AzureStore, error) {
+	connectionString := os.Getenv("AZURE_STORAGE_CONNECTION_STRING")
+	containerName := os.Getenv("AZURE_STORAGE_CONTAINER_NAME")
+	if connectionString == "" || containerName == "" {
+		return nil, errors.New("AZURE_STORAGE_CONNECTION_STRING and AZURE_STORAGE_CONTAINER_NAME must be set when STORAGE_PROVIDER=azure")
+	}
+
+	client, err := azblob.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating Azure Blob client")
+	}
+
+	return &AzureStore{client: client, container: containerName}, nil
+}
+
+func (s *AzureStore) ListSessionObjects(ctx context.Context, projectID int, sessionID int) ([]ObjectRef, error) {
+	prefix := sessionPrefix(projectID, sessionID)
+
+	var refs []ObjectRef
+	pager := s.client.NewListBlobsFlatPager(s.container, &container.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing objects in Azure Blob Storage")
+		}
+		for _, blob := range page.Segment.BlobItems {
+			var size int64
+			if blob.Properties != nil && blob.Properties.ContentLength != nil {
+				size = *blob.Properties.ContentLength
+			}
+			refs = append(refs, ObjectRef{Key: *blob.Name, Size: size})
+		}
+	}
+	return refs, nil
+}
+
+func (s *AzureStore) DeleteObjects(ctx context.Context, objects []ObjectRef) error {
+	for _, object := range objects {
+		if _, err := s.client.DeleteBlob(ctx, s.container, object.Key, nil); err != nil {
+			return errors.Wrap(err, "error deleting blob from Azure")
+		}
+	}
+	return nil
+}
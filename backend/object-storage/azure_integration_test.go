@@ -0,0 +1,37 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAzureStore_ListAndDeleteSessionObjects exercises a real Azure Blob
+// Storage container. Set AZURE_STORAGE_CONNECTION_STRING and
+// AZURE_STORAGE_CONTAINER_NAME via the environment and run with
+// `go test -tags=integration`.
+func TestAzureStore_ListAndDeleteSessionObjects(t *testing.T) {
+	if os.Getenv("AZURE_STORAGE_CONNECTION_STRING") == "" {
+		t.Skip("AZURE_STORAGE_CONNECTION_STRING not configured, skipping Azure integration test")
+	}
+
+	ctx := context.Background()
+	store, err := NewAzureStore(ctx)
+	require.NoError(t, err)
+
+	const projectID, sessionID = 1, 1
+	key := sessionPrefix(projectID, sessionID) + "0/payload.json"
+	_, err = store.client.UploadBuffer(ctx, store.container, key, []byte("test"), nil)
+	require.NoError(t, err)
+
+	objects, err := store.ListSessionObjects(ctx, projectID, sessionID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, objects)
+
+	require.NoError(t, store.DeleteObjects(ctx, objects))
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"os"
+
+	gcs "cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+)
+
+// GCSStore is a SessionPayloadStore backed by Google Cloud Storage.
+type GCSStore struct {
+	client *gcs.Client
+	bucket string
+}
+
+// NewGCSStore creates a GCSStore using application-default credentials.
+// The bucket is read from GCS_SESSIONS_PAYLOAD_BUCKET_NAME.
+func NewGCSStore(ctx context.Context) (*GCSStore, error) {
+	bucket := os.Getenv("GCS_SESSIONS_PAYLOAD_BUCKET_NAME")
+	if bucket == "" {
+		return nil, errors.New("GCS_SESSIONS_PAYLOAD_BUCKET_NAME must be set when STORAGE_PROVIDER=gcs")
+	}
+
+	client, err := gcs.NewClient(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating GCS client")
+	}
+
+	return &GCSStore{client: client, bucket: bucket}, nil
+}
+
+func (s *GCSStore) ListSessionObjects(ctx context.Context, projectID int, sessionID int) ([]ObjectRef, error) {
+	prefix := sessionPrefix(projectID, sessionID)
+	it := s.client.Bucket(s.bucket).Objects(ctx, &gcs.Query{Prefix: prefix})
+
+	var refs []ObjectRef
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing objects in GCS")
+		}
+		refs = append(refs, ObjectRef{Key: attrs.Name, Size: attrs.Size})
+	}
+	return refs, nil
+}
+
+func (s *GCSStore) DeleteObjects(ctx context.Context, objects []ObjectRef) error {
+	for _, object := range objects {
+		if err := s.client.Bucket(s.bucket).Object(object.Key).Delete(ctx); err != nil {
+			return errors.Wrap(err, "error deleting object from GCS")
+		}
+	}
+	return nil
+}
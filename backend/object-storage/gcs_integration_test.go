@@ -0,0 +1,41 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGCSStore_ListAndDeleteSessionObjects exercises a real GCS bucket.
+// Set GCS_SESSIONS_PAYLOAD_BUCKET_NAME and application-default credentials
+// via the environment and run with `go test -tags=integration`.
+func TestGCSStore_ListAndDeleteSessionObjects(t *testing.T) {
+	if os.Getenv("GCS_SESSIONS_PAYLOAD_BUCKET_NAME") == "" {
+		t.Skip("GCS_SESSIONS_PAYLOAD_BUCKET_NAME not configured, skipping GCS integration test")
+	}
+
+	ctx := context.Background()
+	store, err := NewGCSStore(ctx)
+	require.NoError(t, err)
+
+	const projectID, sessionID = 1, 1
+	w := store.client.Bucket(store.bucket).Object(sessionPrefix(projectID, sessionID) + "0/payload.json").NewWriter(ctx)
+	_, err = w.Write([]byte("test"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	objects, err := store.ListSessionObjects(ctx, projectID, sessionID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, objects)
+
+	require.NoError(t, store.DeleteObjects(ctx, objects))
+
+	objects, err = store.ListSessionObjects(ctx, projectID, sessionID)
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
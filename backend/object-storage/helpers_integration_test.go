@@ -0,0 +1,19 @@
+//go:build integration
+
+package storage
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func uploadTestObject(ctx context.Context, client *s3.Client, bucket string, key string) error {
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+		Body:   bytes.NewReader([]byte("test")),
+	})
+	return err
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/pkg/errors"
+)
+
+// MinIOStore is a SessionPayloadStore backed by a MinIO (or other
+// S3-compatible) server, addressed with path-style requests against a
+// custom endpoint and static credentials.
+type MinIOStore struct {
+	*S3Store
+}
+
+// NewMinIOStore creates a MinIOStore from MINIO_ENDPOINT, MINIO_ACCESS_KEY,
+// MINIO_SECRET_KEY, and MINIO_BUCKET_NAME environment variables.
+func NewMinIOStore(ctx context.Context) (*MinIOStore, error) {
+	endpoint := os.Getenv("MINIO_ENDPOINT")
+	accessKey := os.Getenv("MINIO_ACCESS_KEY")
+	secretKey := os.Getenv("MINIO_SECRET_KEY")
+	bucket := os.Getenv("MINIO_BUCKET_NAME")
+	if bucket == "" {
+		bucket = S3SessionsPayloadBucketName
+	}
+
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		UsePathStyle: true,
+		BaseEndpoint: aws.String(endpoint),
+		Credentials:  credentials.NewStaticCredentialsProvider(accessKey, secretKey, ""),
+	})
+
+	if endpoint == "" {
+		return nil, errors.New("MINIO_ENDPOINT must be set when STORAGE_PROVIDER=minio")
+	}
+
+	return &MinIOStore{S3Store: &S3Store{client: client, bucket: bucket}}, nil
+}
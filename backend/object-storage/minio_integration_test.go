@@ -0,0 +1,34 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMinIOStore_ListAndDeleteSessionObjects exercises a real MinIO server.
+// Set MINIO_ENDPOINT, MINIO_ACCESS_KEY, and MINIO_SECRET_KEY via the
+// environment and run with `go test -tags=integration`.
+func TestMinIOStore_ListAndDeleteSessionObjects(t *testing.T) {
+	if os.Getenv("MINIO_ENDPOINT") == "" {
+		t.Skip("MINIO_ENDPOINT not configured, skipping MinIO integration test")
+	}
+
+	ctx := context.Background()
+	store, err := NewMinIOStore(ctx)
+	require.NoError(t, err)
+
+	const projectID, sessionID = 1, 1
+	require.NoError(t, uploadTestObject(ctx, store.client, store.bucket, sessionPrefix(projectID, sessionID)+"0/payload.json"))
+
+	objects, err := store.ListSessionObjects(ctx, projectID, sessionID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, objects)
+
+	require.NoError(t, store.DeleteObjects(ctx, objects))
+}
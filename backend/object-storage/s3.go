@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/pkg/errors"
+)
+
+// S3Store is a SessionPayloadStore backed by AWS S3.
+type S3Store struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewS3Store creates an S3Store using the default AWS credential chain.
+func NewS3Store(ctx context.Context) (*S3Store, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion("us-west-2"))
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading default config")
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
+
+	return &S3Store{client: client, bucket: S3SessionsPayloadBucketName}, nil
+}
+
+func (s *S3Store) ListSessionObjects(ctx context.Context, projectID int, sessionID int) ([]ObjectRef, error) {
+	prefix := sessionPrefix(projectID, sessionID)
+
+	var refs []ObjectRef
+	var continuationToken *string
+	for {
+		output, err := s.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:            &s.bucket,
+			Prefix:            &prefix,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing objects in S3")
+		}
+
+		for _, object := range output.Contents {
+			refs = append(refs, ObjectRef{Key: aws.ToString(object.Key), Size: aws.ToInt64(object.Size)})
+		}
+
+		if !aws.ToBool(output.IsTruncated) {
+			break
+		}
+		continuationToken = output.NextContinuationToken
+	}
+
+	return refs, nil
+}
+
+func (s *S3Store) DeleteObjects(ctx context.Context, objects []ObjectRef) error {
+	for _, object := range objects {
+		key := object.Key
+		if _, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: &s.bucket,
+			Key:    &key,
+		}); err != nil {
+			return errors.Wrap(err, "error deleting object from S3")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestS3Store_ListAndDeleteSessionObjects exercises a real S3 bucket.
+// Set AWS credentials via the environment and run with
+// `go test -tags=integration`; it uses the S3SessionsPayloadBucketName
+// bucket.
+func TestS3Store_ListAndDeleteSessionObjects(t *testing.T) {
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("AWS credentials not configured, skipping S3 integration test")
+	}
+
+	ctx := context.Background()
+	store, err := NewS3Store(ctx)
+	require.NoError(t, err)
+
+	const projectID, sessionID = 1, 1
+	require.NoError(t, uploadTestObject(ctx, store.client, store.bucket, sessionPrefix(projectID, sessionID)+"0/payload.json"))
+
+	objects, err := store.ListSessionObjects(ctx, projectID, sessionID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, objects)
+
+	require.NoError(t, store.DeleteObjects(ctx, objects))
+
+	objects, err = store.ListSessionObjects(ctx, projectID, sessionID)
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/highlight-run/highlight/backend/util"
+	"github.com/pkg/errors"
+)
+
+// S3SessionsPayloadBucketName is the bucket that stores session payloads
+// (events, resources, network requests, etc.) for standard AWS deployments.
+var S3SessionsPayloadBucketName = "highlight-session-payloads"
+
+// ObjectRef identifies a single object within a session-payload bucket.
+type ObjectRef struct {
+	Key  string
+	Size int64
+}
+
+// SessionPayloadStore abstracts the object-storage backend that holds
+// session payloads so that self-hosted deployments aren't locked into AWS
+// S3. Implementations are provided for S3, S3-compatible MinIO, GCS, and
+// Azure Blob Storage.
+type SessionPayloadStore interface {
+	// ListSessionObjects returns every object stored for a given session.
+	ListSessionObjects(ctx context.Context, projectID int, sessionID int) ([]ObjectRef, error)
+	// DeleteObjects removes the given objects from the backing store.
+	DeleteObjects(ctx context.Context, objects []ObjectRef) error
+}
+
+// Provider identifies which SessionPayloadStore implementation to use.
+type Provider string
+
+const (
+	ProviderS3    Provider = "s3"
+	ProviderMinIO Provider = "minio"
+	ProviderGCS   Provider = "gcs"
+	ProviderAzure Provider = "azure"
+)
+
+// NewSessionPayloadStoreFromEnv builds a SessionPayloadStore from the
+// STORAGE_PROVIDER environment variable, defaulting to S3 so existing AWS
+// deployments keep working without any configuration changes.
+func NewSessionPayloadStoreFromEnv(ctx context.Context) (SessionPayloadStore, error) {
+	provider := Provider(os.Getenv("STORAGE_PROVIDER"))
+	if provider == "" {
+		provider = ProviderS3
+	}
+
+	switch provider {
+	case ProviderS3:
+		return NewS3Store(ctx)
+	case ProviderMinIO:
+		return NewMinIOStore(ctx)
+	case ProviderGCS:
+		return NewGCSStore(ctx)
+	case ProviderAzure:
+		return NewAzureStore(ctx)
+	default:
+		return nil, errors.Errorf("unknown STORAGE_PROVIDER %q", provider)
+	}
+}
+
+// sessionPrefix builds the key prefix under which a session's objects are
+// stored, matching the layout used by the client SDK when uploading
+// payloads.
+func sessionPrefix(projectID int, sessionID int) string {
+	devStr := ""
+	if util.IsDevOrTestEnv() {
+		devStr = "dev/"
+	}
+	return fmt.Sprintf("%s%d/%d/", devStr, projectID, sessionID)
+}
@@ -0,0 +1,42 @@
+package presenter
+
+import (
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// Job is the externally-facing representation of a model.DeletionJob,
+// returned by the job status endpoint.
+type Job struct {
+	GUID          string   `json:"guid"`
+	ProjectID     int      `json:"project_id"`
+	Phase         string   `json:"phase"`
+	DryRun        bool     `json:"dry_run"`
+	SessionsTotal int      `json:"sessions_total"`
+	SessionsDone  int      `json:"sessions_done"`
+	Errors        []string `json:"errors,omitempty"`
+}
+
+func jobFromModel(j *model.DeletionJob) *Job {
+	return &Job{
+		GUID:          j.GUID,
+		ProjectID:     j.ProjectID,
+		Phase:         string(j.Phase),
+		DryRun:        j.DryRun,
+		SessionsTotal: j.SessionsTotal,
+		SessionsDone:  j.SessionsDone,
+		Errors:        j.Errors,
+	}
+}
+
+// JobFromGUID loads a DeletionJob by its GUID and converts it to the
+// presenter representation used by the job status endpoint.
+func JobFromGUID(db *gorm.DB, guid string) (*Job, error) {
+	var job model.DeletionJob
+	if err := db.Where(&model.DeletionJob{GUID: guid}).First(&job).Error; err != nil {
+		return nil, errors.Wrap(err, "error querying deletion job")
+	}
+
+	return jobFromModel(&job), nil
+}